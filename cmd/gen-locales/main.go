@@ -0,0 +1,215 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//Command gen-locales turns CLDR-derived locale data into Go source that
+//registers timeago.Config values with timeago.Register, so that
+//applications can pull in many locales via Match/Get instead of
+//hand-writing each Config.
+//
+//The input is a directory of one JSON file per locale (see testdata for
+//examples), each describing the locale's past/future affixes, its
+//plural rule, and the One/Many/Forms text for any of the six periods
+//timeago understands (second, minute, hour, day, month, year). This
+//mirrors the "relative-type" and "relativeTime" blocks of CLDR's
+//dateFields.json, reshaped to match Config/FormatPeriod directly; this
+//repository only ships a handful of illustrative locales under
+//testdata, not the full ~350-locale CLDR corpus.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//jsonPeriod is the on-disk representation of a FormatPeriod.
+type jsonPeriod struct {
+	Unit         string            `json:"unit"`
+	One          string            `json:"one"`
+	Many         string            `json:"many"`
+	Quarter      string            `json:"quarter"`
+	Half         string            `json:"half"`
+	ThreeQuarter string            `json:"threeQuarter"`
+	Forms        map[string]string `json:"forms"`
+}
+
+//jsonLocale is the on-disk representation of a Config.
+type jsonLocale struct {
+	Tag           string       `json:"tag"`
+	PastPrefix    string       `json:"pastPrefix"`
+	PastSuffix    string       `json:"pastSuffix"`
+	FuturePrefix  string       `json:"futurePrefix"`
+	FutureSuffix  string       `json:"futureSuffix"`
+	Zero          string       `json:"zero"`
+	PluralRule    string       `json:"pluralRule"`
+	DefaultLayout string       `json:"defaultLayout"`
+	Periods       []jsonPeriod `json:"periods"`
+}
+
+//unitDuration maps a CLDR field unit to the Go expression timeago uses
+//for its FormatPeriod.D.
+var unitDuration = map[string]string{
+	"second": "time.Second",
+	"minute": "time.Minute",
+	"hour":   "time.Hour",
+	"day":    "Day",
+	"month":  "Month",
+	"year":   "Year",
+}
+
+//pluralRules is the set of plural.Rule names gen-locales accepts in a
+//locale's "pluralRule" field.
+var pluralRules = map[string]bool{
+	"Western": true,
+	"Arabic":  true,
+	"Slavic":  true,
+	"Polish":  true,
+	"Welsh":   true,
+}
+
+func main() {
+	dir := flag.String("data", "", "directory of <tag>.json locale files (see testdata)")
+	out := flag.String("out", "", "output Go file (defaults to stdout)")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatal("gen-locales: -data is required")
+	}
+
+	locales, err := readLocales(*dir)
+	if err != nil {
+		log.Fatalf("gen-locales: %v", err)
+	}
+
+	src, err := generate(locales)
+	if err != nil {
+		log.Fatalf("gen-locales: %v", err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("gen-locales: %v", err)
+	}
+}
+
+//readLocales loads every <tag>.json file in dir, sorted by tag so the
+//generated output is deterministic.
+func readLocales(dir string) ([]jsonLocale, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var locales []jsonLocale
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var locale jsonLocale
+		if err := json.Unmarshal(data, &locale); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		if locale.Tag == "" {
+			return nil, fmt.Errorf("%s: missing \"tag\"", e.Name())
+		}
+		if !pluralRules[locale.PluralRule] {
+			return nil, fmt.Errorf("%s: unknown pluralRule %q", e.Name(), locale.PluralRule)
+		}
+
+		locales = append(locales, locale)
+	}
+
+	sort.Slice(locales, func(i, j int) bool { return locales[i].Tag < locales[j].Tag })
+	return locales, nil
+}
+
+//generate renders locales as a gofmt-ed Go source file that registers
+//one Config per locale in an init function.
+func generate(locales []jsonLocale) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by cmd/gen-locales from CLDR-derived locale data. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package timeago")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `import (`)
+	fmt.Fprintln(&buf, `	"time"`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `	"github.com/simonhege/timeago/plural"`)
+	fmt.Fprintln(&buf, `)`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "func init() {")
+	for _, locale := range locales {
+		writeRegister(&buf, locale)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	return format.Source(buf.Bytes())
+}
+
+func writeRegister(buf *bytes.Buffer, locale jsonLocale) {
+	fmt.Fprintf(buf, "\tRegister(%q, Config{\n", locale.Tag)
+	fmt.Fprintf(buf, "\t\tPastPrefix: %q,\n", locale.PastPrefix)
+	fmt.Fprintf(buf, "\t\tPastSuffix: %q,\n", locale.PastSuffix)
+	fmt.Fprintf(buf, "\t\tFuturePrefix: %q,\n", locale.FuturePrefix)
+	fmt.Fprintf(buf, "\t\tFutureSuffix: %q,\n", locale.FutureSuffix)
+	fmt.Fprintf(buf, "\t\tPeriods: []FormatPeriod{\n")
+	for _, p := range locale.Periods {
+		writePeriod(buf, p)
+	}
+	fmt.Fprintf(buf, "\t\t},\n")
+	fmt.Fprintf(buf, "\t\tPluralRule: plural.%s,\n", locale.PluralRule)
+	fmt.Fprintf(buf, "\t\tZero: %q,\n", locale.Zero)
+	fmt.Fprintf(buf, "\t\tMax: 73 * time.Hour,\n")
+	fmt.Fprintf(buf, "\t\tDefaultLayout: %q,\n", locale.DefaultLayout)
+	fmt.Fprintf(buf, "\t})\n")
+}
+
+func writePeriod(buf *bytes.Buffer, p jsonPeriod) {
+	d, ok := unitDuration[p.Unit]
+	if !ok {
+		d = "time.Second"
+	}
+
+	fmt.Fprintf(buf, "\t\t\t{D: %s, One: %q, Many: %q", d, p.One, p.Many)
+	if p.Quarter != "" {
+		fmt.Fprintf(buf, ", Quarter: %q", p.Quarter)
+	}
+	if p.Half != "" {
+		fmt.Fprintf(buf, ", Half: %q", p.Half)
+	}
+	if p.ThreeQuarter != "" {
+		fmt.Fprintf(buf, ", ThreeQuarter: %q", p.ThreeQuarter)
+	}
+	if len(p.Forms) > 0 {
+		fmt.Fprintf(buf, ", Forms: map[plural.Category]string{")
+		var categories []string
+		for category := range p.Forms {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			fmt.Fprintf(buf, "plural.%s: %q, ", strings.Title(category), p.Forms[category])
+		}
+		fmt.Fprintf(buf, "}")
+	}
+	fmt.Fprintf(buf, "},\n")
+}
@@ -0,0 +1,50 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// Test that the testdata fixtures generate valid, gofmt-ed Go source
+// registering every locale they describe.
+func TestGenerate(t *testing.T) {
+	locales, err := readLocales("testdata")
+	if err != nil {
+		t.Fatalf("readLocales: %v", err)
+	}
+	if len(locales) == 0 {
+		t.Fatal("readLocales: expected at least one locale in testdata")
+	}
+
+	src, err := generate(locales)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "locales_generated.go", src, parser.AllErrors); err != nil {
+		t.Fatalf("generate produced invalid Go source: %v\n%s", err, src)
+	}
+
+	for _, locale := range locales {
+		want := `Register("` + locale.Tag + `"`
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generate: expected output to contain %q", want)
+		}
+	}
+}
+
+// Test that an unknown pluralRule is rejected rather than silently
+// producing an uncompilable plural.<Name> reference.
+func TestReadLocalesRejectsUnknownPluralRule(t *testing.T) {
+	_, err := readLocales("testdata-invalid")
+	if err == nil {
+		t.Fatal("readLocales: expected an error for an unknown pluralRule")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+)
+
+//withMaxUnits returns a copy of cfg with MaxUnits set and no maximum.
+func withMaxUnits(cfg Config, maxUnits int) Config {
+	cfg.MaxUnits = maxUnits
+	return NoMax(cfg)
+}
+
+// Test data for TestCompound
+var compoundTests = []struct {
+	t        time.Time // input time
+	ref      time.Time // input reference
+	cfg      Config    // input config
+	expected string    // expected result
+}{
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(EnglishUS, 2), "1 hour and 5 minutes ago"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(Portuguese, 2), "há 1 hora 5 minutos"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(Chinese, 2), "1 小时 5 分钟前"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(French, 2), "il y a 1 heure et 5 minutes"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(German, 2), "vor 1 Stunde 5 Minuten"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(Turkish, 2), "1 saat 5 dakika önce"},
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(Korean, 2), "1시간 5분 전"},
+
+	// Future
+	{tBase.Add(2*time.Hour + 10*time.Minute), tBase, withMaxUnits(EnglishUS, 2), "in 2 hours and 10 minutes"},
+
+	// MaxUnits caps at the available non-zero components: a clean 2h
+	// delta has nothing left to add as a second unit
+	{tBase, tBase.Add(2 * time.Hour), withMaxUnits(EnglishUS, 2), "2 hours ago"},
+
+	// MaxUnits <= 0 behaves like the historical default of 1
+	{tBase, tBase.Add(1*time.Hour + 5*time.Minute), withMaxUnits(EnglishUS, 0), "about an hour ago"},
+
+	// Upper half of the hour: the primary component must floor rather
+	// than round to nearest, or the count rounds up to 2 and the
+	// remainder goes negative, silently dropping the minutes
+	{tBase, tBase.Add(1*time.Hour + 35*time.Minute), withMaxUnits(EnglishUS, 2), "1 hour and 35 minutes ago"},
+	{tBase, tBase.Add(1*time.Hour + 55*time.Minute), withMaxUnits(EnglishUS, 2), "1 hour and 55 minutes ago"},
+}
+
+// Test the MaxUnits compound rendering
+func TestCompound(t *testing.T) {
+	for i, tt := range compoundTests {
+		actual := tt.cfg.FormatReference(tt.t, tt.ref)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatReference(%s,%s): expected '%s', actual '%s'", i+1, tt.t, tt.ref, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+
+	"github.com/simonhege/timeago/plural"
+)
+
+// ukrainianTest is a minimal Config exercising Config.PluralRule and
+// FormatPeriod.Forms end to end: Ukrainian needs a distinct word for 2
+// hours ("години", few), 5 hours ("годин", many) and 21 hours ("годину",
+// one), which the legacy One/Many split can't tell apart.
+var ukrainianTest = Config{
+	PastPrefix: "",
+	PastSuffix: " тому",
+
+	Periods: []FormatPeriod{
+		{D: time.Second, One: "секунду", Many: "%d секунд"},
+		{D: time.Hour, One: "%d годину", Many: "%d годин",
+			Forms: map[plural.Category]string{
+				plural.One:  "%d годину",
+				plural.Few:  "%d години",
+				plural.Many: "%d годин",
+			}},
+		{D: Day, One: "%d день", Many: "%d днів"},
+	},
+
+	PluralRule: plural.Slavic,
+	Zero:       "щойно",
+
+	DefaultLayout: "2006-01-02",
+}
+
+// Test data for TestForms
+var formsTests = []struct {
+	d        time.Duration // input duration
+	expected string        // expected result
+}{
+	{2 * time.Hour, "2 години тому"},
+	{5 * time.Hour, "5 годин тому"},
+	{21 * time.Hour, "21 годину тому"},
+}
+
+// Test that Config.PluralRule and FormatPeriod.Forms, together, pick a
+// grammatically distinct form per plural category instead of falling
+// back to the legacy One/Many split.
+func TestForms(t *testing.T) {
+	cfg := NoMax(ukrainianTest)
+	for i, tt := range formsTests {
+		actual := cfg.FormatRelativeDuration(tt.d)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatRelativeDuration(%s): expected '%s', actual '%s'", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+)
+
+// Test data for TestFractional
+var fractionalTests = []struct {
+	d        time.Duration // input duration
+	cfg      Config        // input config
+	expected string        // expected result
+}{
+	// HalvesFractional
+	{15 * time.Minute, withFractional(EnglishUS, HalvesFractional), "a quarter of an hour ago"},
+	{30 * time.Minute, withFractional(EnglishUS, HalvesFractional), "half an hour ago"},
+	{45 * time.Minute, withFractional(EnglishUS, HalvesFractional), "three quarters of an hour ago"},
+	{15 * time.Second, withFractional(EnglishUS, HalvesFractional), "a quarter of a minute ago"},
+	{20 * time.Minute, withFractional(EnglishUS, HalvesFractional), "20 minutes ago"},
+
+	// DecimalFractional
+	{90 * time.Minute, withFractional(EnglishUS, DecimalFractional(1)), "1.5 hours ago"},
+	{2 * time.Hour, withFractional(EnglishUS, DecimalFractional(1)), "2 hours ago"},
+}
+
+//withFractional returns a copy of cfg with Fractional set and no maximum.
+func withFractional(cfg Config, f Fractional) Config {
+	cfg.Fractional = f
+	return NoMax(cfg)
+}
+
+// Test fractional count rendering
+func TestFractional(t *testing.T) {
+	for i, tt := range fractionalTests {
+		actual := tt.cfg.FormatRelativeDuration(tt.d)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatRelativeDuration(%s): expected '%s', actual '%s'", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"time"
+)
+
+//Ticker drives the goroutine started by Config.Live. Call Stop to cancel
+//it once the formatted text is no longer needed, eg. when the DOM node
+//or TUI widget it feeds is torn down.
+type Ticker struct {
+	stop chan struct{}
+}
+
+//Live starts a goroutine that calls onUpdate with cfg.Format(t), then
+//reschedules itself for the exact moment that text would next change,
+//instead of polling on a fixed interval: every second while within the
+//Second period, every minute while within the Minute period, and so on,
+//honouring cfg.Thresholds along the way. With cfg.MaxUnits > 1 or
+//cfg.Fractional set, wake-ups still follow the whole-count boundaries
+//of the single-unit text, so trailing-unit or fractional wording that
+//changes in between is only shown at the next wake-up. Call Stop on the
+//returned Ticker to cancel it.
+func (cfg Config) Live(t time.Time, onUpdate func(string)) *Ticker {
+	ticker := &Ticker{stop: make(chan struct{})}
+	go ticker.run(cfg, t, onUpdate)
+	return ticker
+}
+
+//Stop cancels the live updates. It is safe to call Stop more than once.
+func (s *Ticker) Stop() {
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+}
+
+func (s *Ticker) run(cfg Config, t time.Time, onUpdate func(string)) {
+	for {
+		now := time.Now()
+		onUpdate(cfg.FormatReference(t, now))
+
+		elapsed := now.Sub(t)
+		d := elapsed
+		if d < 0 {
+			d = -d
+		}
+		wait := cfg.nextBoundary(d)
+		if cfg.Max > 0 && d >= cfg.Max {
+			if elapsed >= 0 {
+				//Format has fallen back to DefaultLayout and will keep
+				//doing so forever: nothing left to refresh.
+				return
+			}
+			//t is still more than Max in the future: the fuzzy text
+			//starts once it comes within Max.
+			wait = d - cfg.Max + time.Millisecond
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+//nextBoundary returns the duration, measured from the point where the
+//elapsed time is d, until cfg's rendering of d would next change. It
+//mirrors the bucket selection done by getPeriodText, but is unaware of
+//Config.Fractional and Config.MaxUnits: wording changes they introduce
+//within a single period step are not boundaries here, and are picked up
+//only at the next step boundary computed here.
+func (cfg Config) nextBoundary(d time.Duration) time.Duration {
+	const epsilon = time.Millisecond
+
+	if len(cfg.Periods) == 0 {
+		return epsilon
+	}
+
+	if d < cfg.Periods[0].D {
+		return cfg.Periods[0].D - d + epsilon
+	}
+
+	if cfg.SecondsThreshold > 0 && cfg.Periods[0].LessThan != "" {
+		if threshold := time.Duration(cfg.SecondsThreshold) * time.Second; d < threshold {
+			return threshold - d + epsilon
+		}
+	}
+
+	for i, p := range cfg.Periods {
+		next := p.D
+		hasNextPeriod := i+1 < len(cfg.Periods)
+		if hasNextPeriod {
+			next = cfg.Periods[i+1].D
+		}
+
+		if !hasNextPeriod || d < next {
+			step := nextCountBoundary(d, p.D, cfg.RelativeRounding) - d
+
+			if hasNextPeriod {
+				if threshold, ok := cfg.Thresholds[p.D]; ok {
+					if at := time.Duration(threshold)*p.D - d; at < step {
+						step = at
+					}
+				} else if at := next - d; at < step {
+					step = at
+				}
+			}
+
+			return step + epsilon
+		}
+	}
+
+	return cfg.Periods[len(cfg.Periods)-1].D
+}
+
+//nextCountBoundary returns the absolute duration, from t, of the
+//smallest point past d at which round(_, step, mode) reports a bigger
+//count than it does for d.
+func nextCountBoundary(d, step time.Duration, mode RelativeRounding) time.Duration {
+	r := round(d, step, mode)
+
+	switch mode {
+	case RoundFloor:
+		return time.Duration(int64(r)+1) * step
+	case RoundCeil:
+		return r * step
+	default:
+		return time.Duration((float64(r) + 0.5) * float64(step))
+	}
+}
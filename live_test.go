@@ -0,0 +1,88 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+)
+
+// Test data for TestNextBoundary
+var nextBoundaryTests = []struct {
+	d        time.Duration // elapsed time
+	cfg      Config        // input config
+	expected time.Duration // expected time until the text next changes
+}{
+	// Below Periods[0].D, the text is the constant Zero string: the next
+	// change is when it leaves that bucket.
+	{0, NoMax(EnglishUS), time.Second},
+
+	// Within the Second period, the next change is the next rounding
+	// boundary, not a full second away.
+	{30 * time.Second, NoMax(EnglishUS), 500 * time.Millisecond},
+
+	// Within the Minute period.
+	{90 * time.Second, NoMax(EnglishUS), time.Minute},
+
+	// SecondsThreshold postpones the first tick to the threshold itself.
+	{10 * time.Second, withSecondsThreshold(EnglishUS, 45), 35 * time.Second},
+
+	// Thresholds only override the period-to-period collision point;
+	// the count within a period still ticks on its own schedule first.
+	{20 * Day, withThresholds(EnglishUS, map[time.Duration]int{Day: 26}), 12 * time.Hour},
+}
+
+// Test that nextBoundary reports the exact duration until the formatted
+// text would change, without polling.
+func TestNextBoundary(t *testing.T) {
+	for i, tt := range nextBoundaryTests {
+		actual := tt.cfg.nextBoundary(tt.d)
+		// The scheduler pads with a small epsilon to make sure the
+		// boundary has actually passed once the timer fires.
+		actual -= time.Millisecond
+		if actual != tt.expected {
+			t.Errorf("%d) nextBoundary(%s): expected %s, actual %s", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
+
+// Test that Live calls onUpdate immediately and again after Stop is not
+// yet called, then stops updating once Stop is called.
+func TestLive(t *testing.T) {
+	cfg := NoMax(EnglishUS)
+	updates := make(chan string, 10)
+
+	ticker := cfg.Live(time.Now(), func(s string) {
+		updates <- s
+	})
+	defer ticker.Stop()
+
+	select {
+	case <-updates:
+	case <-time.After(time.Second):
+		t.Fatal("Live: expected an immediate update")
+	}
+
+	select {
+	case <-updates:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Live: expected a second update within about a second")
+	}
+
+	ticker.Stop()
+	for drain := true; drain; {
+		select {
+		case <-updates:
+		default:
+			drain = false
+		}
+	}
+
+	select {
+	case s := <-updates:
+		t.Errorf("Live: expected no update after Stop, got %q", s)
+	case <-time.After(1100 * time.Millisecond):
+	}
+}
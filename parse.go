@@ -0,0 +1,177 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ErrNoMatch is returned by ParseDuration when s doesn't match cfg's
+//past/future affixes and any of its period, Zero or fractional forms.
+//Parse does not return it: it falls back to DefaultLayout and returns
+//time.Parse's error instead.
+var ErrNoMatch = errors.New("timeago: string does not match a known fuzzy timestamp")
+
+//ParseDuration parses a fuzzy duration string produced by
+//FormatRelativeDuration (or a reasonable variant, eg. a different count)
+//back into a time.Duration. It uses the same sign convention as the d
+//passed to FormatRelativeDuration: positive for something in the past,
+//negative for something in the future.
+func (cfg Config) ParseDuration(s string) (time.Duration, error) {
+	isPast, body, ok := cfg.stripAffixes(s)
+	if !ok {
+		return 0, ErrNoMatch
+	}
+
+	d, ok := cfg.parseBody(body)
+	if !ok {
+		return 0, ErrNoMatch
+	}
+
+	if !isPast {
+		d = -d
+	}
+	return d, nil
+}
+
+//Parse parses a fuzzy timestamp string produced by FormatReference (or a
+//reasonable variant) back into a time.Time, relative to reference. If s
+//doesn't match any fuzzy pattern, Parse falls back to
+//time.Parse(cfg.DefaultLayout, s).
+func (cfg Config) Parse(s string, reference time.Time) (time.Time, error) {
+	d, err := cfg.ParseDuration(s)
+	if err != nil {
+		return time.Parse(cfg.DefaultLayout, s)
+	}
+	return reference.Add(-d), nil
+}
+
+//Parse is the package-level equivalent of Config.Parse, kept alongside
+//it for symmetry with the package-level Config helpers (NoMax, WithMax).
+func Parse(cfg Config, s string, reference time.Time) (time.Time, error) {
+	return cfg.Parse(s, reference)
+}
+
+//ParseDuration is the package-level equivalent of Config.ParseDuration.
+func ParseDuration(cfg Config, s string) (time.Duration, error) {
+	return cfg.ParseDuration(s)
+}
+
+//stripAffixes checks s against cfg's past and future prefix/suffix
+//pairs, returning the text in between and whether it described the past
+//or the future.
+func (cfg Config) stripAffixes(s string) (isPast bool, body string, ok bool) {
+	if body, ok = stripPrefixSuffix(s, cfg.PastPrefix, cfg.PastSuffix); ok {
+		return true, body, true
+	}
+	if body, ok = stripPrefixSuffix(s, cfg.FuturePrefix, cfg.FutureSuffix); ok {
+		return false, body, true
+	}
+	return false, "", false
+}
+
+func stripPrefixSuffix(s, prefix, suffix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, suffix) {
+		return "", false
+	}
+	return s[len(prefix) : len(s)-len(suffix)], true
+}
+
+//parseBody turns the text between the past/future affixes into a
+//duration, trying cfg.Zero and then each period in turn.
+func (cfg Config) parseBody(body string) (time.Duration, bool) {
+	if cfg.Zero != "" && body == cfg.Zero {
+		return 0, true
+	}
+
+	for _, p := range cfg.Periods {
+		if d, ok := p.parseFixed(body); ok {
+			return d, true
+		}
+		if d, ok := p.parseCounted(body); ok {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+//parseFixed matches the literal, count-less forms of the period: One,
+//Quarter, Half, ThreeQuarter, a parameter-less Many (eg. French's "moins
+//d'une minute"), and any Forms entry without a %d.
+func (p FormatPeriod) parseFixed(body string) (time.Duration, bool) {
+	fixed := []struct {
+		text string
+		frac float64
+	}{
+		{p.One, 1},
+		{p.Many, 1},
+		{p.Quarter, 0.25},
+		{p.Half, 0.5},
+		{p.ThreeQuarter, 0.75},
+	}
+
+	for _, c := range fixed {
+		if c.text != "" && nbParamInFormat(c.text) == 0 && body == c.text {
+			return time.Duration(float64(p.D) * c.frac), true
+		}
+	}
+
+	for _, text := range p.Forms {
+		if text != "" && nbParamInFormat(text) == 0 && body == text {
+			return p.D, true
+		}
+	}
+
+	return 0, false
+}
+
+//parseCounted matches %d-based forms of the period (Many, and any Forms
+//entry with a %d), accepting a fractional count such as "1.5" as
+//produced by Config.Fractional.
+func (p FormatPeriod) parseCounted(body string) (time.Duration, bool) {
+	layouts := []string{p.Many}
+	for _, text := range p.Forms {
+		layouts = append(layouts, text)
+	}
+
+	for _, layout := range layouts {
+		if nbParamInFormat(layout) == 0 {
+			continue
+		}
+
+		re, err := countedPattern(layout)
+		if err != nil {
+			continue
+		}
+
+		m := re.FindStringSubmatch(body)
+		if m == nil {
+			continue
+		}
+
+		count, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+
+		return time.Duration(count * float64(p.D)), true
+	}
+
+	return 0, false
+}
+
+//countedPattern turns a Sprintf-style layout with a single %d
+//placeholder into a regexp capturing the, possibly fractional, count in
+//its place.
+func countedPattern(layout string) (*regexp.Regexp, error) {
+	parts := strings.SplitN(layout, "%d", 2)
+	pattern := "^" + regexp.QuoteMeta(parts[0]) + `(\d+(?:[.,]\d+)?)` + regexp.QuoteMeta(parts[1]) + "$"
+	return regexp.Compile(pattern)
+}
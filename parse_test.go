@@ -0,0 +1,115 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+)
+
+// Test data for TestParseDuration
+var parseDurationTests = []struct {
+	s        string
+	cfg      Config
+	expected time.Duration
+}{
+	// "about a second" is also EnglishUS.Zero, so it parses as 0, same
+	// ambiguity Format itself has for sub-second deltas
+	{"about a second ago", NoMax(EnglishUS), 0},
+	{"2 seconds ago", NoMax(EnglishUS), 2 * time.Second},
+	{"about a minute ago", NoMax(EnglishUS), time.Minute},
+	{"2 minutes ago", NoMax(EnglishUS), 2 * time.Minute},
+	{"about an hour ago", NoMax(EnglishUS), time.Hour},
+	{"2 hours ago", NoMax(EnglishUS), 2 * time.Hour},
+	{"one day ago", NoMax(EnglishUS), Day},
+	{"2 days ago", NoMax(EnglishUS), 2 * Day},
+	{"one year ago", NoMax(EnglishUS), Year},
+
+	{"in one day", NoMax(EnglishUS), -Day},
+	{"in 2 hours", NoMax(EnglishUS), -2 * time.Hour},
+
+	{"vor 2 Stunden", NoMax(German), 2 * time.Hour},
+	{"in 2 Stunden", NoMax(German), -2 * time.Hour},
+	{"há 2 horas", NoMax(Portuguese), 2 * time.Hour},
+	{"2 saat önce", NoMax(Turkish), 2 * time.Hour},
+	{"2 saat içinde", NoMax(Turkish), -2 * time.Hour},
+
+	// Fractional forms should round-trip too
+	{"1.5 hours ago", withFractional(EnglishUS, DecimalFractional(1)), 90 * time.Minute},
+	{"half an hour ago", withFractional(EnglishUS, HalvesFractional), 30 * time.Minute},
+}
+
+// Test the ParseDuration method
+func TestParseDuration(t *testing.T) {
+	for i, tt := range parseDurationTests {
+		actual, err := tt.cfg.ParseDuration(tt.s)
+		if err != nil {
+			t.Errorf("%d) ParseDuration(%q): unexpected error %v", i+1, tt.s, err)
+			continue
+		}
+		if actual != tt.expected {
+			t.Errorf("%d) ParseDuration(%q): expected '%s', actual '%s'", i+1, tt.s, tt.expected, actual)
+		}
+	}
+}
+
+// Test that the package-level Parse and ParseDuration delegate to the
+// Config methods of the same name.
+func TestParsePackageLevel(t *testing.T) {
+	cfg := NoMax(EnglishUS)
+
+	d, err := ParseDuration(cfg, "2 hours ago")
+	if err != nil {
+		t.Fatalf("ParseDuration: unexpected error %v", err)
+	}
+	if d != 2*time.Hour {
+		t.Errorf("ParseDuration: expected %s, actual %s", 2*time.Hour, d)
+	}
+
+	parsed, err := Parse(cfg, "2 hours ago", tBase)
+	if err != nil {
+		t.Fatalf("Parse: unexpected error %v", err)
+	}
+	if !parsed.Equal(tBase.Add(-2 * time.Hour)) {
+		t.Errorf("Parse: expected '%s', actual '%s'", tBase.Add(-2*time.Hour), parsed)
+	}
+}
+
+// Test that ParseDuration and Parse reject text that matches neither the
+// fuzzy forms nor, for Parse, the default layout.
+func TestParseNoMatch(t *testing.T) {
+	if _, err := NoMax(EnglishUS).ParseDuration("not a fuzzy timestamp"); err != ErrNoMatch {
+		t.Errorf("ParseDuration: expected ErrNoMatch, got %v", err)
+	}
+
+	if _, err := NoMax(EnglishUS).Parse("not a fuzzy timestamp", tBase); err == nil {
+		t.Errorf("Parse: expected an error, got none")
+	}
+}
+
+// Test that Parse round-trips FormatReference and falls back to
+// DefaultLayout for dates outside the fuzzy range.
+func TestParse(t *testing.T) {
+	cfg := EnglishUS // keep Max so old dates fall back to DefaultLayout
+
+	formatted := cfg.FormatReference(tBase.Add(-2*time.Hour), tBase)
+	parsed, err := cfg.Parse(formatted, tBase)
+	if err != nil {
+		t.Fatalf("Parse(%q): unexpected error %v", formatted, err)
+	}
+	if !parsed.Equal(tBase.Add(-2 * time.Hour)) {
+		t.Errorf("Parse(%q): expected '%s', actual '%s'", formatted, tBase.Add(-2*time.Hour), parsed)
+	}
+
+	old := tBase.Add(-100 * Day)
+	formatted = cfg.FormatReference(old, tBase)
+	parsed, err = cfg.Parse(formatted, tBase)
+	if err != nil {
+		t.Fatalf("Parse(%q): unexpected error %v", formatted, err)
+	}
+	if parsed.Format(cfg.DefaultLayout) != old.Format(cfg.DefaultLayout) {
+		t.Errorf("Parse(%q): expected date '%s', actual '%s'", formatted, old.Format(cfg.DefaultLayout), parsed.Format(cfg.DefaultLayout))
+	}
+}
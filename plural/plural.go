@@ -0,0 +1,110 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//Package plural implements the CLDR plural categories and a handful of
+//built-in rule functions, so that timeago.Config can pick the grammatically
+//correct form for a given count instead of the legacy binary "one/other"
+//split.
+package plural
+
+//Category is one of the six CLDR plural categories. Most languages only
+//ever produce a subset of them; Western only ever returns One or Other.
+type Category string
+
+const (
+	Zero  Category = "zero"
+	One   Category = "one"
+	Two   Category = "two"
+	Few   Category = "few"
+	Many  Category = "many"
+	Other Category = "other"
+)
+
+//Rule selects the plural category to use for a given count n.
+type Rule func(n int64) Category
+
+//Western is the rule shared by English, German and most Western European
+//languages: One for 1, Other otherwise. It matches the behavior timeago
+//had before CLDR categories were introduced.
+func Western(n int64) Category {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+//Arabic implements the CLDR plural rule for Arabic, as used by the jQuery
+//timeago Arabic override: zero, one, two, a few (n%100 in 3-10), many
+//(n%100 in 11-99) and other.
+func Arabic(n int64) Category {
+	mod100 := n % 100
+	switch {
+	case n == 0:
+		return Zero
+	case n == 1:
+		return One
+	case n == 2:
+		return Two
+	case mod100 >= 3 && mod100 <= 10:
+		return Few
+	case mod100 >= 11 && mod100 <= 99:
+		return Many
+	default:
+		return Other
+	}
+}
+
+//Slavic implements the CLDR plural rule for Russian: one for n%10==1
+//(except the 11 teen), few for n%10 in 2-4 (except the 12-14 teens), many
+//otherwise. Despite the name, this is the Russian rule specifically, not
+//a one-size-fits-all Slavic rule: Polish, for instance, only uses One for
+//n==1 (see Polish).
+func Slavic(n int64) Category {
+	mod10 := n % 10
+	mod100 := n % 100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+//Polish implements the CLDR plural rule for Polish: one for exactly 1,
+//few for n%10 in 2-4 (except the 12-14 teens), many otherwise. Unlike
+//Slavic (Russian), n%10==1 does not get One unless n itself is 1, so eg.
+//21 is Many, not One.
+func Polish(n int64) Category {
+	mod10 := n % 10
+	mod100 := n % 100
+	switch {
+	case n == 1:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+//Welsh implements the CLDR plural rule for Welsh, which, like Arabic,
+//uses all six categories.
+func Welsh(n int64) Category {
+	switch n {
+	case 0:
+		return Zero
+	case 1:
+		return One
+	case 2:
+		return Two
+	case 3:
+		return Few
+	case 6:
+		return Many
+	default:
+		return Other
+	}
+}
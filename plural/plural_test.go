@@ -0,0 +1,70 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package plural
+
+import "testing"
+
+// Test data for TestRules
+var ruleTests = []struct {
+	rule     Rule
+	n        int64
+	expected Category
+}{
+	{Western, 0, Other},
+	{Western, 1, One},
+	{Western, 2, Other},
+	{Western, 11, Other},
+
+	{Arabic, 0, Zero},
+	{Arabic, 1, One},
+	{Arabic, 2, Two},
+	{Arabic, 3, Few},
+	{Arabic, 10, Few},
+	{Arabic, 11, Many},
+	{Arabic, 99, Many},
+	{Arabic, 100, Other},
+	{Arabic, 101, Other},
+	{Arabic, 102, Other},
+
+	{Slavic, 1, One},
+	{Slavic, 21, One},
+	{Slavic, 11, Many},
+	{Slavic, 2, Few},
+	{Slavic, 3, Few},
+	{Slavic, 4, Few},
+	{Slavic, 12, Many},
+	{Slavic, 14, Many},
+	{Slavic, 5, Many},
+	{Slavic, 0, Many},
+
+	{Polish, 1, One},
+	{Polish, 21, Many},
+	{Polish, 31, Many},
+	{Polish, 11, Many},
+	{Polish, 2, Few},
+	{Polish, 3, Few},
+	{Polish, 4, Few},
+	{Polish, 12, Many},
+	{Polish, 14, Many},
+	{Polish, 5, Many},
+	{Polish, 0, Many},
+
+	{Welsh, 0, Zero},
+	{Welsh, 1, One},
+	{Welsh, 2, Two},
+	{Welsh, 3, Few},
+	{Welsh, 6, Many},
+	{Welsh, 4, Other},
+}
+
+// Test the plural rule functions
+func TestRules(t *testing.T) {
+	for i, tt := range ruleTests {
+		actual := tt.rule(tt.n)
+		if actual != tt.expected {
+			t.Errorf("%d) rule(%d): expected '%s', actual '%s'", i+1, tt.n, tt.expected, actual)
+		}
+	}
+}
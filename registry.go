@@ -0,0 +1,64 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import "strings"
+
+//registry holds every Config registered with Register, keyed by
+//lower-cased BCP-47 language tag.
+var registry = map[string]Config{}
+
+//Register makes cfg available under tag (a BCP-47 language tag such as
+//"en" or "pt-BR") through Get and Match. Registering the same tag twice
+//replaces the previous Config.
+func Register(tag string, cfg Config) {
+	registry[normalizeTag(tag)] = cfg
+}
+
+//Get returns the Config registered for tag, and whether one was found.
+//Unlike Match, Get does not fall back to a less specific tag.
+func Get(tag string) (Config, bool) {
+	cfg, ok := registry[normalizeTag(tag)]
+	return cfg, ok
+}
+
+//Match returns the Config for the first of tags that is registered,
+//falling back to each tag's primary language subtag (eg. "pt-BR" falls
+//back to "pt" if "pt-BR" itself isn't registered), and finally to
+//English if none of tags match anything.
+func Match(tags ...string) Config {
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if cfg, ok := registry[tag]; ok {
+			return cfg
+		}
+		if i := strings.IndexByte(tag, '-'); i > 0 {
+			if cfg, ok := registry[tag[:i]]; ok {
+				return cfg
+			}
+		}
+	}
+	return English
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(tag)
+}
+
+//init registers the hand-maintained locales under their BCP-47 tags, so
+//that existing code using English, French, etc. directly keeps working
+//unchanged while Match/Get become the preferred lookup for new code.
+func init() {
+	Register("en", English)
+	Register("en-us", EnglishUS)
+	Register("en-gb", EnglishUK)
+	Register("en-uk", EnglishUK)
+	Register("fr", French)
+	Register("de", German)
+	Register("pt", Portuguese)
+	Register("tr", Turkish)
+	Register("ko", Korean)
+	Register("zh", Chinese)
+}
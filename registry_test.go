@@ -0,0 +1,46 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import "testing"
+
+// Test data for TestMatch
+var matchTests = []struct {
+	tags     []string
+	expected string // PastPrefix of the expected Config, used as a fingerprint
+}{
+	{[]string{"fr"}, French.PastPrefix},
+	{[]string{"fr-FR"}, French.PastPrefix},
+	{[]string{"pt-BR"}, Portuguese.PastPrefix},
+	{[]string{"de-DE", "fr"}, German.PastPrefix},
+	{[]string{"xx-XX"}, English.PastPrefix},
+	{[]string{"xx-XX", "de"}, German.PastPrefix},
+}
+
+// Test the Match function
+func TestMatch(t *testing.T) {
+	for i, tt := range matchTests {
+		actual := Match(tt.tags...).PastPrefix
+		if actual != tt.expected {
+			t.Errorf("%d) Match(%v): expected PastPrefix '%s', actual '%s'", i+1, tt.tags, tt.expected, actual)
+		}
+	}
+}
+
+// Test Get and Register
+func TestGetAndRegister(t *testing.T) {
+	if _, ok := Get("xx"); ok {
+		t.Errorf("Get(\"xx\"): expected no match")
+	}
+
+	Register("xx", English)
+	cfg, ok := Get("xx")
+	if !ok {
+		t.Fatalf("Get(\"xx\"): expected a match after Register")
+	}
+	if cfg.PastPrefix != English.PastPrefix {
+		t.Errorf("Get(\"xx\"): expected English, actual '%+v'", cfg)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2013 Simon HEGE. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package timeago
+
+import (
+	"testing"
+	"time"
+)
+
+// Test data for TestRelativeRounding
+var relativeRoundingTests = []struct {
+	d        time.Duration // input duration
+	cfg      Config        // input config
+	expected string        // expected result
+}{
+	{80 * time.Second, NoMax(EnglishUS), "about a minute ago"},
+	{80 * time.Second, withRounding(EnglishUS, RoundFloor), "about a minute ago"},
+	{80 * time.Second, withRounding(EnglishUS, RoundCeil), "2 minutes ago"},
+
+	{104 * time.Minute, NoMax(EnglishUS), "2 hours ago"},
+	{104 * time.Minute, withRounding(EnglishUS, RoundFloor), "about an hour ago"},
+	{104 * time.Minute, withRounding(EnglishUS, RoundCeil), "2 hours ago"},
+}
+
+//withRounding returns a copy of cfg with RelativeRounding set and no maximum.
+func withRounding(cfg Config, r RelativeRounding) Config {
+	cfg.RelativeRounding = r
+	return NoMax(cfg)
+}
+
+// Test that Config.RelativeRounding controls how a period's raw count is
+// rounded to the integer reported in its text.
+func TestRelativeRounding(t *testing.T) {
+	for i, tt := range relativeRoundingTests {
+		actual := tt.cfg.FormatRelativeDuration(tt.d)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatRelativeDuration(%s): expected '%s', actual '%s'", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
+
+// Test data for TestThresholds
+var thresholdsTests = []struct {
+	d        time.Duration // input duration
+	cfg      Config        // input config
+	expected string        // expected result
+}{
+	{26 * Day, NoMax(EnglishUS), "26 days ago"},
+	{26 * Day, withThresholds(EnglishUS, map[time.Duration]int{Day: 26}), "one month ago"},
+}
+
+//withThresholds returns a copy of cfg with Thresholds set and no maximum.
+func withThresholds(cfg Config, thresholds map[time.Duration]int) Config {
+	cfg.Thresholds = thresholds
+	return NoMax(cfg)
+}
+
+// Test that Config.Thresholds overrides the default proportional
+// period-collision cutoff.
+func TestThresholds(t *testing.T) {
+	for i, tt := range thresholdsTests {
+		actual := tt.cfg.FormatRelativeDuration(tt.d)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatRelativeDuration(%s): expected '%s', actual '%s'", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
+
+// Test data for TestSecondsThreshold
+var secondsThresholdTests = []struct {
+	d        time.Duration // input duration
+	cfg      Config        // input config
+	expected string        // expected result
+}{
+	{30 * time.Second, NoMax(EnglishUS), "30 seconds ago"},
+	{30 * time.Second, withSecondsThreshold(EnglishUS, 45), "less than 45 seconds ago"},
+	{50 * time.Second, withSecondsThreshold(EnglishUS, 45), "50 seconds ago"},
+}
+
+//withSecondsThreshold returns a copy of cfg with SecondsThreshold set and a
+//LessThan form on its first period, and no maximum.
+func withSecondsThreshold(cfg Config, seconds int) Config {
+	periods := make([]FormatPeriod, len(cfg.Periods))
+	copy(periods, cfg.Periods)
+	periods[0].LessThan = "less than %d seconds"
+	cfg.Periods = periods
+	cfg.SecondsThreshold = seconds
+	return NoMax(cfg)
+}
+
+// Test that Config.SecondsThreshold switches the Second period to its
+// LessThan form below the configured number of seconds.
+func TestSecondsThreshold(t *testing.T) {
+	for i, tt := range secondsThresholdTests {
+		actual := tt.cfg.FormatRelativeDuration(tt.d)
+		if actual != tt.expected {
+			t.Errorf("%d) FormatRelativeDuration(%s): expected '%s', actual '%s'", i+1, tt.d, tt.expected, actual)
+		}
+	}
+}
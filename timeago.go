@@ -11,8 +11,12 @@ package timeago
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/simonhege/timeago/plural"
 )
 
 const (
@@ -25,6 +29,29 @@ type FormatPeriod struct {
 	D    time.Duration
 	One  string
 	Many string
+
+	//Forms maps CLDR plural categories to the text to output for this
+	//period. It is only consulted when the Config has a PluralRule set.
+	//Outside compound output, plural.One always uses One when it is set,
+	//even if Forms has a plural.One entry; other categories absent from
+	//the map fall back to Many. In compound output
+	//(Config.MaxUnits > 1), the fuzzy One string is never used, so a
+	//Forms entry for plural.One is the way to give a period its own
+	//precise singular text, eg. "%d hour" rather than the approximate
+	//"about an hour"; without one, plural.One falls back to Many.
+	Forms map[plural.Category]string
+
+	//Quarter, Half and ThreeQuarter are used instead of One/Many when
+	//Config.Fractional is HalvesFractional and the duration is less than
+	//D but close to one of those fractions of it, eg. "half an hour ago".
+	Quarter      string
+	Half         string
+	ThreeQuarter string
+
+	//LessThan is used instead of One/Many when Config.SecondsThreshold
+	//applies, eg. "less than %d seconds". It takes a single %d parameter:
+	//the threshold itself, not the actual count.
+	LessThan string
 }
 
 //Config allows the customization of timeago.
@@ -38,6 +65,50 @@ type Config struct {
 
 	Periods []FormatPeriod
 
+	//PluralRule selects the CLDR plural category used to pick a
+	//FormatPeriod's Forms entry. When nil, the legacy One/Many split
+	//based on the count being exactly 1 is used instead.
+	PluralRule plural.Rule
+
+	//Fractional controls whether getTimeText may express a count that
+	//isn't close to a whole number of periods, instead of always
+	//rounding to the nearest unit. Defaults to NoFractional.
+	Fractional Fractional
+
+	//MaxUnits controls how many non-zero period components are joined
+	//into the output, eg. MaxUnits: 2 turns a 1h05m delta into "1 hour
+	//and 5 minutes ago" with English instead of "about an hour ago". Zero
+	//or less means 1,
+	//the historical behavior.
+	MaxUnits int
+
+	//UnitSeparator joins the text of two adjacent period components when
+	//MaxUnits allows more than one. Defaults to " ".
+	UnitSeparator string
+
+	//LastUnitSeparator, if non-empty, replaces UnitSeparator between the
+	//last two components, eg. " and " for English or " et " for French.
+	LastUnitSeparator string
+
+	//RelativeRounding controls how a period's raw count is rounded to
+	//the integer reported in its text. Defaults to RoundNearest, the
+	//historical behavior.
+	RelativeRounding RelativeRounding
+
+	//Thresholds overrides, per period duration, the count at which
+	//getTimeText switches over to the next bigger period instead of the
+	//default cutoff, where the rounded count reaches one whole next
+	//period (eg. 30 days for Day), eg.
+	//Thresholds: map[time.Duration]int{Day: 26} reports "one month ago"
+	//from 26 days on instead of "26 days ago". Thresholds are not applied
+	//in compound output (MaxUnits > 1).
+	Thresholds map[time.Duration]int
+
+	//SecondsThreshold, if greater than zero, is the number of seconds
+	//under which the Second period's LessThan form is used instead of
+	//One/Many, eg. "less than 45 seconds ago".
+	SecondsThreshold int
+
 	Zero string
 	Max  time.Duration //Maximum duration for using the special formatting.
 	//DefaultLayout is used if delta is greater than the minimum of last period
@@ -53,21 +124,42 @@ var English = Config{
 	FuturePrefix: "in ",
 	FutureSuffix: "",
 
+	LastUnitSeparator: " and ",
+
 	Periods: []FormatPeriod{
-		{D: time.Second, One: "about a second", Many: "a second%d seconds"},
-		{D: time.Minute, One: "about a minute", Many: "%d minutes"},
-		{D: time.Hour, One: "about an hour", Many: "%d hours"},
-		{D: Day, One: "one day", Many: "%d days"},
-		{D: Month, One: "one month", Many: "%d months"},
-		{D: Year, One: "one year", Many: "%d years"},
+		{D: time.Second, One: "about a second", Many: "%d seconds",
+			Forms: map[plural.Category]string{plural.One: "%d second"}},
+		{D: time.Minute, One: "about a minute", Many: "%d minutes",
+			Quarter: "a quarter of a minute", Half: "half a minute", ThreeQuarter: "three quarters of a minute",
+			Forms: map[plural.Category]string{plural.One: "%d minute"}},
+		{D: time.Hour, One: "about an hour", Many: "%d hours",
+			Quarter: "a quarter of an hour", Half: "half an hour", ThreeQuarter: "three quarters of an hour",
+			Forms: map[plural.Category]string{plural.One: "%d hour"}},
+		{D: Day, One: "one day", Many: "%d days",
+			Forms: map[plural.Category]string{plural.One: "%d day"}},
+		{D: Month, One: "one month", Many: "%d months",
+			Forms: map[plural.Category]string{plural.One: "%d month"}},
+		{D: Year, One: "one year", Many: "%d years",
+			Forms: map[plural.Category]string{plural.One: "%d year"}},
 	},
 
-	Zero: "about a second",
+	PluralRule: plural.Western,
+	Zero:       "about a second",
 
 	Max:           73 * time.Hour,
 	DefaultLayout: "2006-01-02",
 }
 
+//EnglishUS is an alias of English, kept for symmetry with EnglishUK: the
+//fuzzy strings used here don't differ between American and British
+//English.
+var EnglishUS = English
+
+//EnglishUK is an alias of English, kept for symmetry with EnglishUS: the
+//fuzzy strings used here don't differ between American and British
+//English.
+var EnglishUK = English
+
 var Portuguese = Config{
 	PastPrefix:   "há ",
 	PastSuffix:   "",
@@ -75,15 +167,22 @@ var Portuguese = Config{
 	FutureSuffix: "",
 
 	Periods: []FormatPeriod{
-		{D: time.Second, One: "um segundo", Many: "%d segundos"},
-		{D: time.Minute, One: "um minuto", Many: "%d minutos"},
-		{D: time.Hour, One: "uma hora", Many: "%d horas"},
-		{D: Day, One: "um dia", Many: "%d dias"},
-		{D: Month, One: "um mês", Many: "%d meses"},
-		{D: Year, One: "um ano", Many: "%d anos"},
+		{D: time.Second, One: "um segundo", Many: "%d segundos",
+			Forms: map[plural.Category]string{plural.One: "%d segundo"}},
+		{D: time.Minute, One: "um minuto", Many: "%d minutos",
+			Forms: map[plural.Category]string{plural.One: "%d minuto"}},
+		{D: time.Hour, One: "uma hora", Many: "%d horas",
+			Forms: map[plural.Category]string{plural.One: "%d hora"}},
+		{D: Day, One: "um dia", Many: "%d dias",
+			Forms: map[plural.Category]string{plural.One: "%d dia"}},
+		{D: Month, One: "um mês", Many: "%d meses",
+			Forms: map[plural.Category]string{plural.One: "%d mês"}},
+		{D: Year, One: "um ano", Many: "%d anos",
+			Forms: map[plural.Category]string{plural.One: "%d ano"}},
 	},
 
-	Zero: "menos de um segundo",
+	PluralRule: plural.Western,
+	Zero:       "menos de um segundo",
 
 	Max:           73 * time.Hour,
 	DefaultLayout: "02-01-2006",
@@ -117,16 +216,25 @@ var French = Config{
 	FuturePrefix: "dans ",
 	FutureSuffix: "",
 
+	LastUnitSeparator: " et ",
+
 	Periods: []FormatPeriod{
-		{D: time.Second, One: "environ une seconde", Many: "moins d'une minute"},
-		{D: time.Minute, One: "environ une minute", Many: "%d minutes"},
-		{D: time.Hour, One: "environ une heure", Many: "%d heures"},
-		{D: Day, One: "un jour", Many: "%d jours"},
-		{D: Month, One: "un mois", Many: "%d mois"},
-		{D: Year, One: "un an", Many: "%d ans"},
+		{D: time.Second, One: "environ une seconde", Many: "moins d'une minute",
+			Forms: map[plural.Category]string{plural.One: "%d seconde"}},
+		{D: time.Minute, One: "environ une minute", Many: "%d minutes",
+			Forms: map[plural.Category]string{plural.One: "%d minute"}},
+		{D: time.Hour, One: "environ une heure", Many: "%d heures",
+			Forms: map[plural.Category]string{plural.One: "%d heure"}},
+		{D: Day, One: "un jour", Many: "%d jours",
+			Forms: map[plural.Category]string{plural.One: "%d jour"}},
+		{D: Month, One: "un mois", Many: "%d mois",
+			Forms: map[plural.Category]string{plural.One: "%d mois"}},
+		{D: Year, One: "un an", Many: "%d ans",
+			Forms: map[plural.Category]string{plural.One: "%d an"}},
 	},
 
-	Zero: "environ une seconde",
+	PluralRule: plural.Western,
+	Zero:       "environ une seconde",
 
 	Max:           73 * time.Hour,
 	DefaultLayout: "02/01/2006",
@@ -140,15 +248,22 @@ var German = Config{
 	FutureSuffix: "",
 
 	Periods: []FormatPeriod{
-		{D: time.Second, One: "einer Sekunde", Many: "%d Sekunden"},
-		{D: time.Minute, One: "einer Minute", Many: "%d Minuten"},
-		{D: time.Hour, One: "einer Stunde", Many: "%d Stunden"},
-		{D: Day, One: "einem Tag", Many: "%d Tagen"},
-		{D: Month, One: "einem Monat", Many: "%d Monaten"},
-		{D: Year, One: "einem Jahr", Many: "%d Jahren"},
+		{D: time.Second, One: "einer Sekunde", Many: "%d Sekunden",
+			Forms: map[plural.Category]string{plural.One: "%d Sekunde"}},
+		{D: time.Minute, One: "einer Minute", Many: "%d Minuten",
+			Forms: map[plural.Category]string{plural.One: "%d Minute"}},
+		{D: time.Hour, One: "einer Stunde", Many: "%d Stunden",
+			Forms: map[plural.Category]string{plural.One: "%d Stunde"}},
+		{D: Day, One: "einem Tag", Many: "%d Tagen",
+			Forms: map[plural.Category]string{plural.One: "%d Tag"}},
+		{D: Month, One: "einem Monat", Many: "%d Monaten",
+			Forms: map[plural.Category]string{plural.One: "%d Monat"}},
+		{D: Year, One: "einem Jahr", Many: "%d Jahren",
+			Forms: map[plural.Category]string{plural.One: "%d Jahr"}},
 	},
 
-	Zero: "einer Sekunde",
+	PluralRule: plural.Western,
+	Zero:       "einer Sekunde",
 
 	Max:           73 * time.Hour,
 	DefaultLayout: "02.01.2006",
@@ -227,7 +342,7 @@ func (cfg Config) FormatRelativeDuration(d time.Duration) string {
 		d = -d
 	}
 
-	s, _ := cfg.getTimeText(d, true)
+	s, _ := cfg.getTimeText(d)
 
 	if isPast {
 		return strings.Join([]string{cfg.PastPrefix, s, cfg.PastSuffix}, "")
@@ -236,14 +351,38 @@ func (cfg Config) FormatRelativeDuration(d time.Duration) string {
 
 }
 
-//Round the duration d in terms of step.
-func round(d time.Duration, step time.Duration, roundCloser bool) time.Duration {
+//RelativeRounding controls how a period's raw count is rounded to the
+//integer reported in its text.
+type RelativeRounding int
 
-	if roundCloser {
-		return time.Duration(float64(d)/float64(step) + 0.5)
-	}
+const (
+	//RoundNearest rounds to the closest integer count, eg. 104 minutes
+	//is reported as "2 hours". This is the historical behavior and the
+	//zero value of RelativeRounding.
+	RoundNearest RelativeRounding = iota
+
+	//RoundFloor always truncates towards zero, eg. 104 minutes is
+	//reported as "about an hour".
+	RoundFloor
+
+	//RoundCeil always rounds up away from zero, eg. 80 seconds is
+	//reported as "2 minutes".
+	RoundCeil
+)
+
+//Round the duration d in terms of step, using mode.
+func round(d time.Duration, step time.Duration, mode RelativeRounding) time.Duration {
+
+	q := float64(d) / float64(step)
 
-	return time.Duration(float64(d) / float64(step))
+	switch mode {
+	case RoundFloor:
+		return time.Duration(q)
+	case RoundCeil:
+		return time.Duration(math.Ceil(q))
+	default:
+		return time.Duration(q + 0.5)
+	}
 }
 
 //Count the number of parameters in a format string
@@ -251,35 +390,123 @@ func nbParamInFormat(f string) int {
 	return strings.Count(f, "%") - 2*strings.Count(f, "%%")
 }
 
-//Convert a duration to a text, based on the current config
-func (cfg Config) getTimeText(d time.Duration, roundCloser bool) (string, time.Duration) {
+//Convert a duration to a text, based on the current config. Joins up to
+//cfg.MaxUnits non-zero period components, eg. "1 hour and 5 minutes" for
+//a 1h05m delta with English and MaxUnits: 2.
+func (cfg Config) getTimeText(d time.Duration) (string, time.Duration) {
 	if len(cfg.Periods) == 0 || d < cfg.Periods[0].D {
 		return cfg.Zero, 0
 	}
 
+	maxUnits := cfg.MaxUnits
+	if maxUnits <= 0 {
+		maxUnits = 1
+	}
+	compound := maxUnits > 1
+
+	text, remainder := cfg.getPeriodText(d, true, compound)
+	parts := []string{text}
+
+	for i := 1; i < maxUnits && remainder > 0; i++ {
+		next, r := cfg.getPeriodText(remainder, false, compound)
+		if next == "" {
+			break
+		}
+		parts = append(parts, next)
+		remainder = r
+	}
+
+	return cfg.joinUnits(parts), remainder
+}
+
+//joinUnits joins the text of multiple period components with
+//cfg.UnitSeparator, using cfg.LastUnitSeparator (if set) between the
+//last two.
+func (cfg Config) joinUnits(parts []string) string {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	sep := cfg.UnitSeparator
+	if sep == "" {
+		sep = " "
+	}
+	lastSep := cfg.LastUnitSeparator
+	if lastSep == "" {
+		lastSep = sep
+	}
+
+	s := parts[0]
+	for i := 1; i < len(parts); i++ {
+		if i == len(parts)-1 {
+			s += lastSep + parts[i]
+		} else {
+			s += sep + parts[i]
+		}
+	}
+	return s
+}
+
+//getPeriodText renders d as a single period component. Outside compound
+//output, the primary (outermost) component honours Config.Thresholds,
+//the period-collision skip and Config.Fractional. In compound output
+//(Config.MaxUnits > 1) the primary component floors its count and none
+//of those apply; trailing components always report the rounded whole
+//count of their period.
+func (cfg Config) getPeriodText(d time.Duration, primary bool, compound bool) (string, time.Duration) {
 	for i, p := range cfg.Periods {
 
 		next := p.D
-		if i+1 < len(cfg.Periods) {
+		hasNextPeriod := i+1 < len(cfg.Periods)
+		if hasNextPeriod {
 			next = cfg.Periods[i+1].D
 		}
 
-		if i+1 == len(cfg.Periods) || d < next {
+		if primary && i == 0 && p.LessThan != "" && cfg.SecondsThreshold > 0 &&
+			d < time.Duration(cfg.SecondsThreshold)*time.Second {
+			return fmt.Sprintf(p.LessThan, cfg.SecondsThreshold), 0
+		}
+
+		if !hasNextPeriod || d < next {
 
-			r := round(d, p.D, roundCloser)
+			rounding := cfg.RelativeRounding
+			//A compound primary must floor rather than round to nearest:
+			//rounding up would make d-r*p.D negative and silently drop
+			//every trailing unit (eg. 1h35m rounding to "2 hours").
+			if primary && compound {
+				rounding = RoundFloor
+			}
+			r := round(d, p.D, rounding)
+
+			if primary && !compound && hasNextPeriod {
+				if threshold, ok := cfg.Thresholds[p.D]; ok {
+					if int(r) >= threshold {
+						continue
+					}
+				} else if r == round(next, p.D, rounding) {
+					continue
+				}
+			}
 
-			if next != p.D && r == round(next, p.D, roundCloser) {
-				continue
+			if primary && !compound && cfg.Fractional.mode != fractionalNone {
+				if text, remainder, ok := cfg.fractionalText(p, d); ok {
+					return text, remainder
+				}
+				//Halves alone also considers the next bigger period, so
+				//that eg. 30 minutes can be phrased as "half an hour"
+				//instead of just falling back to "30 minutes".
+				if cfg.Fractional.mode == fractionalHalves && hasNextPeriod {
+					if text, remainder, ok := cfg.fractionalText(cfg.Periods[i+1], d); ok {
+						return text, remainder
+					}
+				}
 			}
 
 			if r == 0 {
 				return "", d
 			}
 
-			layout := p.Many
-			if r == 1 {
-				layout = p.One
-			}
+			layout := cfg.periodLayout(p, int64(r), compound)
 
 			if nbParamInFormat(layout) == 0 {
 				return layout, d - r*p.D
@@ -292,6 +519,111 @@ func (cfg Config) getTimeText(d time.Duration, roundCloser bool) (string, time.D
 	return d.String(), 0
 }
 
+//periodLayout picks the textual form to use for count n, honouring a
+//configured PluralRule and the period's Forms before falling back to the
+//legacy One/Many split. compound is true while building Config.MaxUnits
+//output: it suppresses the fuzzy One fallback, since pairing an
+//approximate head ("about an hour") with an exact tail ("5 minutes") is
+//self-contradictory — compound output uses the Forms entry for the
+//category, or Many, instead.
+func (cfg Config) periodLayout(p FormatPeriod, n int64, compound bool) string {
+	if cfg.PluralRule != nil && len(p.Forms) > 0 {
+		category := cfg.PluralRule(n)
+		if !compound && category == plural.One && p.One != "" {
+			return p.One
+		}
+		if layout, ok := p.Forms[category]; ok {
+			return layout
+		}
+		return p.Many
+	}
+
+	if !compound && n == 1 {
+		return p.One
+	}
+	return p.Many
+}
+
+//Fractional controls whether getTimeText may express a count that isn't
+//close to a whole number of periods, instead of always rounding to the
+//nearest unit.
+type Fractional struct {
+	mode   fractionalMode
+	digits int
+}
+
+type fractionalMode int
+
+const (
+	fractionalNone fractionalMode = iota
+	fractionalHalves
+	fractionalDecimal
+)
+
+//NoFractional disables fractional counts. It is the zero value of
+//Fractional and the default for every bundled Config.
+var NoFractional = Fractional{mode: fractionalNone}
+
+//HalvesFractional renders a locale-specific Quarter/Half/ThreeQuarter
+//form (see FormatPeriod) when the duration is less than a period but
+//close to one of those fractions of it, eg. "half an hour ago".
+var HalvesFractional = Fractional{mode: fractionalHalves}
+
+//DecimalFractional renders the count as a decimal number with the given
+//number of digits after the point instead of rounding to the nearest
+//whole unit, eg. DecimalFractional(1) turns 1h30m into "1.5 hours ago".
+func DecimalFractional(digits int) Fractional {
+	return Fractional{mode: fractionalDecimal, digits: digits}
+}
+
+//nearFraction reports whether f is close enough to target to be
+//considered that fraction, allowing for the imprecision of real-world
+//durations (eg. 29m30s being "half an hour").
+func nearFraction(f, target float64) bool {
+	const epsilon = 0.05
+	return f > target-epsilon && f < target+epsilon
+}
+
+//fractionalText renders d in terms of period p using cfg.Fractional,
+//reporting ok=false when the fractional mode doesn't apply (so the
+//caller should fall back to the regular whole-unit formatting, possibly
+//against a different period).
+func (cfg Config) fractionalText(p FormatPeriod, d time.Duration) (string, time.Duration, bool) {
+	raw := float64(d) / float64(p.D)
+	whole := int64(raw)
+	frac := raw - float64(whole)
+
+	switch cfg.Fractional.mode {
+	case fractionalHalves:
+		if whole != 0 {
+			return "", 0, false
+		}
+		switch {
+		case p.Quarter != "" && nearFraction(frac, 0.25):
+			return p.Quarter, 0, true
+		case p.Half != "" && nearFraction(frac, 0.5):
+			return p.Half, 0, true
+		case p.ThreeQuarter != "" && nearFraction(frac, 0.75):
+			return p.ThreeQuarter, 0, true
+		}
+		return "", 0, false
+
+	case fractionalDecimal:
+		if nearFraction(frac, 0) || nearFraction(frac, 1) {
+			return "", 0, false
+		}
+
+		count := strconv.FormatFloat(raw, 'f', cfg.Fractional.digits, 64)
+		layout := p.Many
+		if nbParamInFormat(layout) == 0 {
+			return layout, 0, true
+		}
+		return strings.Replace(layout, "%d", count, 1), 0, true
+	}
+
+	return "", 0, false
+}
+
 //NoMax creates an new config without a maximum
 func NoMax(cfg Config) Config {
 	return WithMax(cfg, 9223372036854775807, time.RFC3339)